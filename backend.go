@@ -0,0 +1,84 @@
+package tickler
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+)
+
+// ErrEmpty is returned by Backend.Pop when there are no events to hand out.
+var ErrEmpty = errors.New("tickler: backend is empty")
+
+// Backend is a pluggable store for queued events. The default backend
+// keeps everything in memory; a durable backend (Redis, SQLite, ...) lets
+// jobs survive a process restart at the cost of only persisting Request
+// metadata, not the BackgroundFn closure itself. Durable backends resolve
+// BackgroundFn on Pop via a JobRegistry the caller registers at boot.
+type Backend interface {
+	Push(event *Event) error
+	Pop() (*Event, error)
+	Ack(job JobName) error
+	Nack(job JobName, err error) error
+	Len() int
+}
+
+// JobRegistry maps a JobName to the BackgroundFn that implements it. Durable
+// backends cannot serialize closures, so a restarted process must re-wire
+// each job name to its implementation before Start is called.
+type JobRegistry map[JobName]BackgroundFn
+
+// memoryBackend is the default Backend: an in-process priority heap. It is
+// the only backend that does not survive a restart.
+type memoryBackend struct {
+	mu    sync.Mutex
+	queue priorityQueue
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{queue: make(priorityQueue, 0)}
+}
+
+func (b *memoryBackend) Push(event *Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	heap.Push(&b.queue, event)
+	return nil
+}
+
+func (b *memoryBackend) Pop() (*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.queue.Len() == 0 {
+		return nil, ErrEmpty
+	}
+
+	// Aging may have changed the relative order of waiting events since
+	// they were pushed, so restore heap order before popping.
+	heap.Init(&b.queue)
+	return heap.Pop(&b.queue).(*Event), nil
+}
+
+func (b *memoryBackend) Ack(job JobName) error { return nil }
+
+func (b *memoryBackend) Nack(job JobName, err error) error { return nil }
+
+func (b *memoryBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queue.Len()
+}
+
+// drainAll pops every event currently held by the backend, without
+// processing them. Used by Shutdown to hand back unstarted work.
+func drainAll(b Backend) []*Event {
+	var events []*Event
+	for {
+		event, err := b.Pop()
+		if err != nil {
+			break
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,137 @@
+package tickler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownJobPolicy controls what happens when an event waits for a job
+// name that isn't currently tracked by the Tickler (WaitForJobs, IfSuccess,
+// or IfFailure naming a job that was never enqueued, or already finished
+// and was cleaned up).
+type UnknownJobPolicy int
+
+const (
+	// UnknownJobWait is the default: the event waits indefinitely, same
+	// as if the dependency simply hadn't completed yet.
+	UnknownJobWait UnknownJobPolicy = iota
+	// UnknownJobFail rejects the Enqueue outright with ErrUnknownJob.
+	UnknownJobFail
+	// UnknownJobIgnore drops the unknown dependency and proceeds as if it
+	// had never been named.
+	UnknownJobIgnore
+)
+
+// SetUnknownJobPolicy controls how Enqueue and EnqueueWithContext treat a
+// dependency naming a job the Tickler isn't currently tracking.
+func (s *Tickler) SetUnknownJobPolicy(policy UnknownJobPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unknownJobPolicy = policy
+}
+
+// ErrUnknownJob is returned by Enqueue/EnqueueWithContext under
+// UnknownJobFail when a dependency names a job the Tickler isn't
+// currently tracking.
+type ErrUnknownJob struct {
+	Job       JobName
+	DependsOn JobName
+}
+
+func (e *ErrUnknownJob) Error() string {
+	return fmt.Sprintf("tickler: %q depends on unknown job %q", e.Job, e.DependsOn)
+}
+
+// ErrDependencyCycle is returned by Enqueue/EnqueueWithContext when adding
+// the event would create a cycle in the job dependency graph.
+type ErrDependencyCycle struct {
+	Job  JobName
+	Path []JobName
+}
+
+func (e *ErrDependencyCycle) Error() string {
+	return fmt.Sprintf("tickler: enqueueing %q would create a dependency cycle: %s",
+		e.Job, strings.Join(append(e.Path, e.Job), " -> "))
+}
+
+// resolveDependencies applies the Tickler's UnknownJobPolicy to event's
+// waitFor/ifSuccess/ifFailure lists, filtering or rejecting names that
+// aren't currently tracked. Must be called with s.mu held.
+func (s *Tickler) resolveDependencies(event *Event) error {
+	if s.unknownJobPolicy == UnknownJobWait {
+		return nil
+	}
+
+	check := func(names []JobName) ([]JobName, error) {
+		var kept []JobName
+		for _, n := range names {
+			if _, ok := s.currentJobs[n]; ok {
+				kept = append(kept, n)
+				continue
+			}
+			if s.unknownJobPolicy == UnknownJobFail {
+				return nil, &ErrUnknownJob{Job: event.Job, DependsOn: n}
+			}
+			// UnknownJobIgnore: drop n, but still tell observers so the
+			// drop isn't invisible to anything watching OnDrop.
+			s.notifyDrop(event, &ErrUnknownJob{Job: event.Job, DependsOn: n})
+		}
+		return kept, nil
+	}
+
+	var err error
+	if event.fnOpts.waitFor, err = check(event.fnOpts.waitFor); err != nil {
+		return err
+	}
+	if event.fnOpts.ifSuccess, err = check(event.fnOpts.ifSuccess); err != nil {
+		return err
+	}
+	if event.fnOpts.ifFailure, err = check(event.fnOpts.ifFailure); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkCycle walks backwards from each of event's dependencies through the
+// existing dependency graph; if event's own job name is reachable, adding
+// it would close a cycle. Must be called with s.mu held.
+func (s *Tickler) checkCycle(event *Event) error {
+	deps := make([]JobName, 0, len(event.fnOpts.waitFor)+len(event.fnOpts.ifSuccess)+len(event.fnOpts.ifFailure))
+	deps = append(deps, event.fnOpts.waitFor...)
+	deps = append(deps, event.fnOpts.ifSuccess...)
+	deps = append(deps, event.fnOpts.ifFailure...)
+
+	visited := make(map[JobName]bool)
+	var path []JobName
+
+	var dfs func(current JobName) bool
+	dfs = func(current JobName) bool {
+		if current == event.Job {
+			return true
+		}
+		if visited[current] {
+			return false
+		}
+		visited[current] = true
+		path = append(path, current)
+
+		for _, next := range s.deps[current] {
+			if dfs(next) {
+				return true
+			}
+		}
+
+		path = path[:len(path)-1]
+		return false
+	}
+
+	for _, dep := range deps {
+		path = path[:0]
+		if dfs(dep) {
+			return &ErrDependencyCycle{Job: event.Job, Path: append([]JobName{}, path...)}
+		}
+	}
+
+	s.deps[event.Job] = deps
+	return nil
+}
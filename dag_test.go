@@ -0,0 +1,50 @@
+package tickler
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCycleRejectsDirectCycle(t *testing.T) {
+	tk := New()
+	// No Start(): these never need to actually run to exercise cycle
+	// detection, which happens synchronously inside Enqueue.
+
+	if err := tk.Enqueue(Request{Job: "a", F: func() error { return nil }}, WaitForJobs("b")); err != nil {
+		t.Fatalf("enqueue a: %v", err)
+	}
+
+	err := tk.Enqueue(Request{Job: "b", F: func() error { return nil }}, WaitForJobs("a"))
+	var cycleErr *ErrDependencyCycle
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected *ErrDependencyCycle, got %v", err)
+	}
+}
+
+func TestCheckCycleAllowsDiamondDependency(t *testing.T) {
+	tk := New()
+
+	if err := tk.Enqueue(Request{Job: "root", F: func() error { return nil }}); err != nil {
+		t.Fatalf("enqueue root: %v", err)
+	}
+	if err := tk.Enqueue(Request{Job: "left", F: func() error { return nil }}, WaitForJobs("root")); err != nil {
+		t.Fatalf("enqueue left: %v", err)
+	}
+	if err := tk.Enqueue(Request{Job: "right", F: func() error { return nil }}, WaitForJobs("root")); err != nil {
+		t.Fatalf("enqueue right: %v", err)
+	}
+	if err := tk.Enqueue(Request{Job: "join", F: func() error { return nil }}, WaitForJobs("left", "right")); err != nil {
+		t.Fatalf("enqueue join (diamond dependency, not a cycle): %v", err)
+	}
+}
+
+func TestResolveDependenciesUnknownJobFailRejectsEnqueue(t *testing.T) {
+	tk := New()
+	tk.SetUnknownJobPolicy(UnknownJobFail)
+
+	err := tk.Enqueue(Request{Job: "waiter", F: func() error { return nil }}, WaitForJobs("nonexistent"))
+	var unknownErr *ErrUnknownJob
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("expected *ErrUnknownJob, got %v", err)
+	}
+}
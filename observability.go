@@ -0,0 +1,123 @@
+package tickler
+
+import "log"
+
+// Logger is the minimal structured logging interface Tickler uses
+// internally instead of calling log.Printf directly, so callers can route
+// its output through their own logging stack.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// stdLogger adapts the standard library log package to Logger and is the
+// default used when no Logger is configured.
+type stdLogger struct{}
+
+func (stdLogger) Debugf(format string, args ...any) { log.Printf(format, args...) }
+func (stdLogger) Infof(format string, args ...any)  { log.Printf(format, args...) }
+func (stdLogger) Errorf(format string, args ...any) { log.Printf(format, args...) }
+
+// Observer receives lifecycle notifications for every event a Tickler
+// handles. Hooks are called synchronously from the goroutine that
+// triggered them: the enqueueing goroutine for OnEnqueue; the service
+// loop's goroutine (or Drain's caller) for OnDequeue; the event's own
+// worker goroutine for OnStart/OnComplete/OnRetry; and for OnDrop,
+// whichever goroutine dropped the event (the enqueueing goroutine for an
+// ignored unknown dependency, the worker for a failed dependency,
+// Shutdown's caller for unstarted events, or a retry timer's goroutine for
+// a retry dropped during shutdown). Implementations must return quickly.
+type Observer interface {
+	OnEnqueue(event *Event)
+	OnDequeue(event *Event)
+	OnStart(event *Event)
+	OnComplete(event *Event, err error)
+	OnRetry(event *Event, attempt int, err error)
+	OnDrop(event *Event, reason error)
+}
+
+// NoopObserver implements Observer with no-ops. Embed it in a custom
+// Observer to only override the hooks you care about.
+type NoopObserver struct{}
+
+func (NoopObserver) OnEnqueue(event *Event)                       {}
+func (NoopObserver) OnDequeue(event *Event)                       {}
+func (NoopObserver) OnStart(event *Event)                         {}
+func (NoopObserver) OnComplete(event *Event, err error)           {}
+func (NoopObserver) OnRetry(event *Event, attempt int, err error) {}
+func (NoopObserver) OnDrop(event *Event, reason error)            {}
+
+type tickerConfig struct {
+	logger    Logger
+	observers []Observer
+}
+
+// TicklerOption configures a Tickler at construction time, via New or
+// NewWithBackend.
+type TicklerOption interface {
+	apply(*tickerConfig)
+}
+
+type ticklerOption struct {
+	f func(*tickerConfig)
+}
+
+func (o *ticklerOption) apply(c *tickerConfig) {
+	o.f(c)
+}
+
+func newTicklerOption(f func(*tickerConfig)) *ticklerOption {
+	return &ticklerOption{f: f}
+}
+
+// WithLogger replaces the standard library logger Tickler uses internally.
+func WithLogger(logger Logger) TicklerOption {
+	return newTicklerOption(func(c *tickerConfig) {
+		c.logger = logger
+	})
+}
+
+// WithObserver registers an additional Observer. Multiple observers may be
+// registered; all are notified of every hook.
+func WithObserver(observer Observer) TicklerOption {
+	return newTicklerOption(func(c *tickerConfig) {
+		c.observers = append(c.observers, observer)
+	})
+}
+
+func (s *Tickler) notifyEnqueue(event *Event) {
+	for _, o := range s.observers {
+		o.OnEnqueue(event)
+	}
+}
+
+func (s *Tickler) notifyDequeue(event *Event) {
+	for _, o := range s.observers {
+		o.OnDequeue(event)
+	}
+}
+
+func (s *Tickler) notifyStart(event *Event) {
+	for _, o := range s.observers {
+		o.OnStart(event)
+	}
+}
+
+func (s *Tickler) notifyComplete(event *Event, err error) {
+	for _, o := range s.observers {
+		o.OnComplete(event, err)
+	}
+}
+
+func (s *Tickler) notifyRetry(event *Event, attempt int, err error) {
+	for _, o := range s.observers {
+		o.OnRetry(event, attempt, err)
+	}
+}
+
+func (s *Tickler) notifyDrop(event *Event, reason error) {
+	for _, o := range s.observers {
+		o.OnDrop(event, reason)
+	}
+}
@@ -0,0 +1,98 @@
+package tickler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	NoopObserver
+
+	mu      sync.Mutex
+	dropped []error
+}
+
+func (o *recordingObserver) OnDrop(event *Event, reason error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.dropped = append(o.dropped, reason)
+}
+
+func (o *recordingObserver) drops() []error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]error(nil), o.dropped...)
+}
+
+func TestFailedDependencyDropsEventViaOnDrop(t *testing.T) {
+	obs := &recordingObserver{}
+	tk := New(WithObserver(obs))
+	tk.Start()
+
+	if err := tk.Enqueue(Request{Job: "dep", F: func() error { return errDependencyFailed }}); err != nil {
+		t.Fatalf("enqueue dep: %v", err)
+	}
+	if err := tk.Enqueue(Request{
+		Job: "waiter",
+		F:   func() error { return nil },
+	}, IfSuccess("dep")); err != nil {
+		t.Fatalf("enqueue waiter: %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for len(obs.drops()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for OnDrop to fire for the failed-dependency path")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestUnknownJobIgnoreReportsOnDrop(t *testing.T) {
+	obs := &recordingObserver{}
+	tk := New(WithObserver(obs))
+	tk.SetUnknownJobPolicy(UnknownJobIgnore)
+
+	if err := tk.Enqueue(Request{Job: "waiter", F: func() error { return nil }}, WaitForJobs("nonexistent")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	drops := obs.drops()
+	if len(drops) != 1 {
+		t.Fatalf("expected exactly one OnDrop call for the ignored dependency, got %d", len(drops))
+	}
+	unknown, ok := drops[0].(*ErrUnknownJob)
+	if !ok {
+		t.Fatalf("expected *ErrUnknownJob, got %T", drops[0])
+	}
+	if unknown.DependsOn != "nonexistent" {
+		t.Errorf("DependsOn = %q, want %q", unknown.DependsOn, "nonexistent")
+	}
+}
+
+func TestShutdownReportsOnDropForUnstartedEvents(t *testing.T) {
+	obs := &recordingObserver{}
+	tk := New(WithObserver(obs))
+	// Intentionally do not Start(): the event stays queued, unstarted.
+
+	if err := tk.Enqueue(Request{Job: "never-runs", F: func() error { return nil }}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := tk.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	drops := obs.drops()
+	if len(drops) != 1 {
+		t.Fatalf("expected one OnDrop call for the drained event, got %d", len(drops))
+	}
+	if drops[0] != ErrShutdown {
+		t.Errorf("drop reason = %v, want %v", drops[0], ErrShutdown)
+	}
+}
@@ -0,0 +1,63 @@
+package tickler
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelObserver starts an OpenTelemetry span per event, using the event's
+// own context as parent, and links the span to any WaitForJobs
+// dependencies still tracked in-process so traces show the dependency
+// graph rather than a flat list of jobs.
+type OTelObserver struct {
+	NoopObserver
+
+	tracer trace.Tracer
+
+	mu    sync.Mutex
+	spans map[JobName]trace.Span
+}
+
+// NewOTelObserver returns an Observer that records a span per event under
+// the given tracer name. Pass the result to tickler.WithObserver.
+func NewOTelObserver(tracerName string) *OTelObserver {
+	return &OTelObserver{
+		tracer: otel.Tracer(tracerName),
+		spans:  make(map[JobName]trace.Span),
+	}
+}
+
+func (o *OTelObserver) OnStart(event *Event) {
+	o.mu.Lock()
+	links := make([]trace.Link, 0, len(event.fnOpts.waitFor))
+	for _, dep := range event.fnOpts.waitFor {
+		if span, ok := o.spans[dep]; ok {
+			links = append(links, trace.Link{SpanContext: span.SpanContext()})
+		}
+	}
+	o.mu.Unlock()
+
+	_, span := o.tracer.Start(event.ctx, event.Job, trace.WithLinks(links...))
+
+	o.mu.Lock()
+	o.spans[event.Job] = span
+	o.mu.Unlock()
+}
+
+func (o *OTelObserver) OnComplete(event *Event, err error) {
+	o.mu.Lock()
+	span, ok := o.spans[event.Job]
+	delete(o.spans, event.Job)
+	o.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
@@ -0,0 +1,103 @@
+package tickler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PersistedEvent is the serializable subset of an Event's metadata that a
+// durable Backend writes to storage. It deliberately excludes the
+// BackgroundFn closure, the context, and the wait/result channels, none of
+// which survive a process restart; those are reconstructed by toEvent
+// using a caller-supplied JobRegistry. RetryIf is also excluded: it's a
+// func(error) bool and can't be serialized, so a resumed event retries
+// every error regardless of the classifier the original WithRetryIf set.
+type PersistedEvent struct {
+	Job            JobName       `json:"job"`
+	Priority       Priority      `json:"priority"`
+	Weight         int           `json:"weight"`
+	WaitFor        []JobName     `json:"wait_for,omitempty"`
+	IfSuccess      []JobName     `json:"if_success,omitempty"`
+	IfFailure      []JobName     `json:"if_failure,omitempty"`
+	EnqueueSeq     uint64        `json:"enqueue_seq"`
+	EnqueuedAt     time.Time     `json:"enqueued_at"`
+	AgingThreshold time.Duration `json:"aging_threshold"`
+	Timeout        time.Duration `json:"timeout"`
+
+	RetryMaxAttempts int           `json:"retry_max_attempts,omitempty"`
+	RetryBaseDelay   time.Duration `json:"retry_base_delay,omitempty"`
+	RetryMaxDelay    time.Duration `json:"retry_max_delay,omitempty"`
+	RetryJitter      bool          `json:"retry_jitter,omitempty"`
+	Attempt          int           `json:"attempt,omitempty"`
+}
+
+func toPersisted(event *Event) PersistedEvent {
+	p := PersistedEvent{
+		Job:            event.Job,
+		Priority:       event.priority,
+		Weight:         event.weight,
+		WaitFor:        event.fnOpts.waitFor,
+		IfSuccess:      event.fnOpts.ifSuccess,
+		IfFailure:      event.fnOpts.ifFailure,
+		EnqueueSeq:     event.enqueueSeq,
+		EnqueuedAt:     event.enqueuedAt,
+		AgingThreshold: event.fnOpts.agingThreshold,
+		Timeout:        event.fnOpts.timeout,
+		Attempt:        event.attempt,
+	}
+
+	if r := event.fnOpts.retry; r != nil {
+		p.RetryMaxAttempts = r.MaxAttempts
+		p.RetryBaseDelay = r.BaseDelay
+		p.RetryMaxDelay = r.MaxDelay
+		p.RetryJitter = r.Jitter
+	}
+
+	return p
+}
+
+// toEvent resolves the persisted metadata back into a runnable Event,
+// looking up its BackgroundFn in registry by Job name.
+func (p PersistedEvent) toEvent(registry JobRegistry) (*Event, error) {
+	f, ok := registry[p.Job]
+	if !ok {
+		return nil, fmt.Errorf("tickler: no job registered for %q, cannot resume from backend", p.Job)
+	}
+
+	event := &Event{
+		fnOpts: &eventOptions{
+			waitFor:        p.WaitFor,
+			ifSuccess:      p.IfSuccess,
+			ifFailure:      p.IfFailure,
+			priority:       p.Priority,
+			weight:         p.Weight,
+			agingThreshold: p.AgingThreshold,
+			timeout:        p.Timeout,
+		},
+		f:          f,
+		Job:        p.Job,
+		parentCtx:  context.Background(),
+		priority:   p.Priority,
+		weight:     p.Weight,
+		enqueueSeq: p.EnqueueSeq,
+		enqueuedAt: p.EnqueuedAt,
+		attempt:    p.Attempt,
+		ch:         make(chan struct{}),
+		result:     statusSuccess,
+		resultCh:   make(chan status),
+	}
+
+	if p.RetryMaxAttempts > 0 {
+		event.fnOpts.retry = &RetryPolicy{
+			MaxAttempts: p.RetryMaxAttempts,
+			BaseDelay:   p.RetryBaseDelay,
+			MaxDelay:    p.RetryMaxDelay,
+			Jitter:      p.RetryJitter,
+		}
+	}
+
+	event.beginAttempt()
+
+	return event, nil
+}
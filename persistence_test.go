@@ -0,0 +1,45 @@
+package tickler
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPersistedEventRoundTripsRetryPolicy(t *testing.T) {
+	registry := JobRegistry{"job": func() error { return nil }}
+
+	event := newEvent(context.Background(), Request{Job: "job", F: registry["job"]}, 1, 0,
+		WithRetry(5, 0, 0, true))
+	event.attempt = 2
+
+	restored, err := toPersisted(event).toEvent(registry)
+	if err != nil {
+		t.Fatalf("toEvent: %v", err)
+	}
+
+	if restored.fnOpts.retry == nil {
+		t.Fatal("expected retry policy to survive the round trip")
+	}
+	if restored.fnOpts.retry.MaxAttempts != 5 {
+		t.Errorf("MaxAttempts = %d, want 5", restored.fnOpts.retry.MaxAttempts)
+	}
+	if !restored.fnOpts.retry.Jitter {
+		t.Error("Jitter = false, want true")
+	}
+	if restored.attempt != 2 {
+		t.Errorf("attempt = %d, want 2", restored.attempt)
+	}
+}
+
+func TestPersistedEventWithoutRetryPolicyStaysNil(t *testing.T) {
+	registry := JobRegistry{"job": func() error { return nil }}
+	event := newEvent(context.Background(), Request{Job: "job", F: registry["job"]}, 1, 0)
+
+	restored, err := toPersisted(event).toEvent(registry)
+	if err != nil {
+		t.Fatalf("toEvent: %v", err)
+	}
+	if restored.fnOpts.retry != nil {
+		t.Fatalf("expected no retry policy, got %+v", restored.fnOpts.retry)
+	}
+}
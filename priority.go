@@ -0,0 +1,87 @@
+package tickler
+
+import (
+	"time"
+)
+
+// Priority controls the order in which events are dequeued. Higher values
+// are dequeued first.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+	PriorityCritical
+)
+
+const (
+	// defaultAgingThreshold is how long an event can wait before its
+	// effective priority is bumped by one level to prevent starvation.
+	defaultAgingThreshold = 30 * time.Second
+)
+
+// priorityQueue is a min-heap ordered by (-effective priority, virtual
+// sequence), so the highest-priority, oldest event is always at the root.
+type priorityQueue []*Event
+
+func (pq priorityQueue) Len() int { return len(pq) }
+
+func (pq priorityQueue) Less(i, j int) bool {
+	pi, pj := pq[i].effectivePriority(), pq[j].effectivePriority()
+	if pi != pj {
+		return pi > pj
+	}
+	return pq[i].virtualSeq() < pq[j].virtualSeq()
+}
+
+func (pq priorityQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *priorityQueue) Push(x any) {
+	*pq = append(*pq, x.(*Event))
+}
+
+func (pq *priorityQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	event := old[n-1]
+	old[n-1] = nil
+	*pq = old[:n-1]
+	return event
+}
+
+// effectivePriority returns the event's priority after applying aging: an
+// event that has waited longer than its AgingThreshold has its priority
+// bumped by one level for every threshold interval elapsed, capped at
+// PriorityCritical.
+func (e *Event) effectivePriority() Priority {
+	if e.fnOpts.agingThreshold <= 0 {
+		return e.priority
+	}
+
+	waited := time.Since(e.enqueuedAt)
+	bumps := int(waited / e.fnOpts.agingThreshold)
+	if bumps <= 0 {
+		return e.priority
+	}
+
+	bumped := e.priority + Priority(bumps)
+	if bumped > PriorityCritical {
+		return PriorityCritical
+	}
+	return bumped
+}
+
+// virtualSeq is the tie-breaker used within a priority band: dividing
+// enqueueSeq by weight pulls a heavier event's effective position earlier in
+// the queue, so among events of equal effective priority a weight-2 event is
+// dequeued roughly twice as often as a weight-1 event competing with it.
+// Weight has no effect across priority bands; it only arbitrates fairness
+// within one.
+func (e *Event) virtualSeq() float64 {
+	w := e.weight
+	if w <= 0 {
+		w = 1
+	}
+	return float64(e.enqueueSeq) / float64(w)
+}
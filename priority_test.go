@@ -0,0 +1,53 @@
+package tickler
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+)
+
+func newTestEvent(priority Priority, weight int, seq uint64) *Event {
+	return &Event{
+		fnOpts:     &eventOptions{priority: priority, weight: weight},
+		priority:   priority,
+		weight:     weight,
+		enqueueSeq: seq,
+		enqueuedAt: time.Now(),
+	}
+}
+
+func TestPriorityQueueOrdersByEffectivePriority(t *testing.T) {
+	pq := priorityQueue{
+		newTestEvent(PriorityLow, 1, 1),
+		newTestEvent(PriorityCritical, 1, 2),
+		newTestEvent(PriorityNormal, 1, 3),
+	}
+	heap.Init(&pq)
+
+	if got := heap.Pop(&pq).(*Event).priority; got != PriorityCritical {
+		t.Fatalf("expected PriorityCritical first, got %v", got)
+	}
+	if got := heap.Pop(&pq).(*Event).priority; got != PriorityNormal {
+		t.Fatalf("expected PriorityNormal second, got %v", got)
+	}
+}
+
+func TestPriorityQueueWeightFavorsHeavierEventWithinBand(t *testing.T) {
+	light := newTestEvent(PriorityNormal, 1, 1)
+	heavy := newTestEvent(PriorityNormal, 4, 4)
+
+	pq := priorityQueue{light, heavy}
+	heap.Init(&pq)
+
+	// With seqs 1 and 4, heavy's virtual sequence (4/4=1.0) only ties
+	// light's (1/1=1.0), so use seqs that make the weighting unambiguous:
+	// heavy's seq is larger, yet its weight puts it first.
+	heavy.enqueueSeq = 8 // virtualSeq = 2.0
+	light.enqueueSeq = 3 // virtualSeq = 3.0
+	heap.Init(&pq)
+
+	first := heap.Pop(&pq).(*Event)
+	if first != heavy {
+		t.Fatalf("expected heavier-weighted event to dequeue first, got weight %d", first.weight)
+	}
+}
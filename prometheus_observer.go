@@ -0,0 +1,85 @@
+package tickler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusObserver reports Tickler lifecycle events as Prometheus
+// metrics: a counter of enqueued jobs, a counter of completed jobs by
+// status, a histogram of job durations, and gauges for queue depth and
+// semaphore utilization.
+type PrometheusObserver struct {
+	NoopObserver
+
+	enqueued  prometheus.Counter
+	completed *prometheus.CounterVec
+	duration  prometheus.Histogram
+
+	mu     sync.Mutex
+	starts map[JobName]time.Time
+}
+
+// NewPrometheusObserver registers its metrics with reg and returns an
+// Observer that keeps them updated for t. Pass the result to
+// tickler.WithObserver.
+func NewPrometheusObserver(reg prometheus.Registerer, t *Tickler) *PrometheusObserver {
+	o := &PrometheusObserver{
+		enqueued: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tickler_jobs_enqueued_total",
+			Help: "Total number of jobs enqueued.",
+		}),
+		completed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tickler_jobs_completed_total",
+			Help: "Total number of jobs completed, labeled by status.",
+		}, []string{"status"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: "tickler_job_duration_seconds",
+			Help: "Job execution duration in seconds, from OnStart to OnComplete.",
+		}),
+		starts: make(map[JobName]time.Time),
+	}
+
+	queueLen := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "tickler_queue_length",
+		Help: "Number of events currently queued, not yet started.",
+	}, func() float64 { return float64(t.backend.Len()) })
+
+	semInUse := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "tickler_semaphore_in_use",
+		Help: "Number of worker slots currently in use.",
+	}, func() float64 { return float64(len(t.options.sema)) })
+
+	reg.MustRegister(o.enqueued, o.completed, o.duration, queueLen, semInUse)
+
+	return o
+}
+
+func (o *PrometheusObserver) OnEnqueue(event *Event) {
+	o.enqueued.Inc()
+}
+
+func (o *PrometheusObserver) OnStart(event *Event) {
+	o.mu.Lock()
+	o.starts[event.Job] = time.Now()
+	o.mu.Unlock()
+}
+
+func (o *PrometheusObserver) OnComplete(event *Event, err error) {
+	o.mu.Lock()
+	start, ok := o.starts[event.Job]
+	delete(o.starts, event.Job)
+	o.mu.Unlock()
+
+	if ok {
+		o.duration.Observe(time.Since(start).Seconds())
+	}
+
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	o.completed.WithLabelValues(status).Inc()
+}
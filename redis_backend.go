@@ -0,0 +1,117 @@
+package tickler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// priorityScale spaces priority bands far enough apart in a RedisBackend's
+// ZSET score that no realistic enqueueSeq run (up to 1e15 enqueues at one
+// priority) bleeds into the next band.
+const priorityScale = 1e15
+
+// priorityScore maps priority and enqueueSeq onto a ZSET score ordered so
+// ZPopMin always returns the highest-priority, oldest-enqueued job first,
+// matching SQLiteBackend.Pop's ORDER BY priority DESC, enqueue_seq ASC.
+// Unlike the in-memory backend's priorityQueue, this score is fixed at
+// enqueue time: the effective-priority aging done by Event.effectivePriority
+// isn't re-applied on a ZSET, so a job enqueued against a RedisBackend does
+// not get its priority bumped for having waited.
+func priorityScore(p Priority, seq uint64) float64 {
+	return float64(-p)*priorityScale + float64(seq)
+}
+
+// RedisBackend persists queued events as a Redis ZSET of job names ordered
+// by priorityScore, a hash of serialized PersistedEvent metadata, and a
+// dead-letter list of terminally failed job names, so jobs survive a
+// process restart. BackgroundFn closures are never serialized; Pop resolves
+// them from the registry supplied at construction.
+type RedisBackend struct {
+	client   *redis.Client
+	queueKey string
+	metaKey  string
+	deadKey  string
+	registry JobRegistry
+	ctx      context.Context
+}
+
+// NewRedisBackend returns a Backend backed by client, namespacing its keys
+// under namespace so multiple Ticklers can share a Redis instance.
+func NewRedisBackend(client *redis.Client, namespace string, registry JobRegistry) *RedisBackend {
+	return &RedisBackend{
+		client:   client,
+		queueKey: namespace + ":queue",
+		metaKey:  namespace + ":meta",
+		deadKey:  namespace + ":dead",
+		registry: registry,
+		ctx:      context.Background(),
+	}
+}
+
+func (b *RedisBackend) Push(event *Event) error {
+	payload, err := json.Marshal(toPersisted(event))
+	if err != nil {
+		return fmt.Errorf("tickler: marshal event for redis: %w", err)
+	}
+
+	pipe := b.client.TxPipeline()
+	pipe.HSet(b.ctx, b.metaKey, event.Job, payload)
+	pipe.ZAdd(b.ctx, b.queueKey, redis.Z{
+		Score:  priorityScore(event.priority, event.enqueueSeq),
+		Member: event.Job,
+	})
+	_, err = pipe.Exec(b.ctx)
+	return err
+}
+
+func (b *RedisBackend) Pop() (*Event, error) {
+	popped, err := b.client.ZPopMin(b.ctx, b.queueKey, 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("tickler: pop from redis: %w", err)
+	}
+	if len(popped) == 0 {
+		return nil, ErrEmpty
+	}
+	job := popped[0].Member.(string)
+
+	payload, err := b.client.HGet(b.ctx, b.metaKey, job).Result()
+	if err != nil {
+		return nil, fmt.Errorf("tickler: load metadata for %q: %w", job, err)
+	}
+
+	var persisted PersistedEvent
+	if err := json.Unmarshal([]byte(payload), &persisted); err != nil {
+		return nil, fmt.Errorf("tickler: unmarshal event for %q: %w", job, err)
+	}
+
+	return persisted.toEvent(b.registry)
+}
+
+func (b *RedisBackend) Ack(job JobName) error {
+	return b.client.HDel(b.ctx, b.metaKey, job).Err()
+}
+
+// Nack is only ever called for a job that has permanently failed: a
+// retryable failure re-enters the queue directly via scheduleRetry's call
+// to Push, never through Nack. So Nack must not re-queue job — doing so
+// would send a job that will never succeed back through Pop forever. It
+// instead records job on the dead-letter list for operator inspection and
+// drops its metadata.
+func (b *RedisBackend) Nack(job JobName, cause error) error {
+	pipe := b.client.TxPipeline()
+	pipe.RPush(b.ctx, b.deadKey, job)
+	pipe.HDel(b.ctx, b.metaKey, job)
+	_, err := pipe.Exec(b.ctx)
+	return err
+}
+
+func (b *RedisBackend) Len() int {
+	n, err := b.client.ZCard(b.ctx, b.queueKey).Result()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,24 @@
+package tickler
+
+import "testing"
+
+// priorityScore is pure and requires no Redis client, so it's tested
+// directly; exercising Push/Pop/Nack needs a live redis.Client, which this
+// package doesn't otherwise depend on in tests.
+func TestPriorityScoreOrdersHigherPriorityFirst(t *testing.T) {
+	critical := priorityScore(PriorityCritical, 100)
+	low := priorityScore(PriorityLow, 1)
+
+	if critical >= low {
+		t.Fatalf("expected a critical-priority job enqueued later to still score below a low-priority job, got critical=%v low=%v", critical, low)
+	}
+}
+
+func TestPriorityScoreBreaksTiesByEnqueueSeq(t *testing.T) {
+	first := priorityScore(PriorityNormal, 1)
+	second := priorityScore(PriorityNormal, 2)
+
+	if first >= second {
+		t.Fatalf("expected the earlier enqueueSeq to score lower within the same priority band, got first=%v second=%v", first, second)
+	}
+}
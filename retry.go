@@ -0,0 +1,131 @@
+package tickler
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how an event is re-scheduled after f() returns an
+// error.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      bool
+
+	// RetryIf classifies whether an error is transient and worth
+	// retrying. A nil RetryIf retries every error.
+	RetryIf func(error) bool
+}
+
+func (p *RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.RetryIf != nil && !p.RetryIf(err) {
+		return false
+	}
+	return true
+}
+
+// backoff returns the delay before the given attempt (1-indexed), computed
+// as min(BaseDelay*2^(attempt-1), MaxDelay) plus uniform jitter in
+// [0, delay/2) when Jitter is enabled. Doubling is applied one step at a
+// time so a large attempt saturates at MaxDelay (or at the largest
+// representable Duration, with no MaxDelay set) instead of overflowing
+// int64 and wrapping around to a near-zero or negative delay.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+		if delay > time.Duration(math.MaxInt64)/2 {
+			delay = time.Duration(math.MaxInt64)
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if !p.Jitter || delay/2 <= 0 {
+		return delay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay / 2)))
+	if jitter > time.Duration(math.MaxInt64)-delay {
+		// delay is already saturated (no MaxDelay set): adding jitter would
+		// overflow int64 and wrap to a negative duration, which fires
+		// time.AfterFunc immediately instead of waiting.
+		return time.Duration(math.MaxInt64)
+	}
+	return delay + jitter
+}
+
+// WithRetry configures the event to be re-scheduled up to maxAttempts
+// times on failure, with exponential backoff between attempts.
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration, jitter bool) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		t.retry = &RetryPolicy{
+			MaxAttempts: maxAttempts,
+			BaseDelay:   baseDelay,
+			MaxDelay:    maxDelay,
+			Jitter:      jitter,
+		}
+	})
+}
+
+// WithRetryIf sets a classifier used to decide whether a given error is
+// worth retrying. Must be used alongside WithRetry.
+func WithRetryIf(retryIf func(error) bool) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		if t.retry != nil {
+			t.retry.RetryIf = retryIf
+		}
+	})
+}
+
+// scheduleRetry re-enqueues the event after its backoff delay, incrementing
+// its attempt count. It does not occupy a worker slot while waiting, but it
+// does hold s.wg open for the wait so Shutdown's wg.Wait() doesn't return
+// while a retry is still pending; if Shutdown runs first, the pending retry
+// is dropped instead of being pushed back onto a backend Shutdown already
+// drained.
+func (s *Tickler) scheduleRetry(event *Event, err error) {
+	event.attempt++
+	delay := event.fnOpts.retry.backoff(event.attempt)
+
+	s.logger.Infof("retrying job %v after error (attempt %d/%d, delay %s): %v",
+		event.Job, event.attempt, event.fnOpts.retry.MaxAttempts, delay, err)
+
+	s.wg.Add(1)
+	time.AfterFunc(delay, func() {
+		defer s.wg.Done()
+
+		s.mu.Lock()
+		if !s.accepting {
+			s.mu.Unlock()
+			s.logger.Infof("dropping retry for job %v: tickler is shutting down", event.Job)
+			s.notifyDrop(event, ErrShutdown)
+			return
+		}
+		s.enqueueSeq++
+		event.enqueueSeq = s.enqueueSeq
+		event.enqueuedAt = time.Now()
+		pushErr := s.backend.Push(event)
+		s.mu.Unlock()
+
+		if pushErr != nil {
+			s.logger.Errorf("failed to re-enqueue retried job %v: %v", event.Job, pushErr)
+			return
+		}
+		s.tickleLoop()
+	})
+}
@@ -0,0 +1,130 @@
+package tickler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoffCapsAtMaxDelay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	if got := p.backoff(1); got != time.Second {
+		t.Fatalf("attempt 1: got %s, want %s", got, time.Second)
+	}
+	if got := p.backoff(4); got != 8*time.Second {
+		t.Fatalf("attempt 4: got %s, want %s", got, 8*time.Second)
+	}
+	if got := p.backoff(5); got != 10*time.Second {
+		t.Fatalf("attempt 5: got %s, want %s (capped)", got, 10*time.Second)
+	}
+}
+
+func TestRetryPolicyBackoffDoesNotOverflowOnLargeAttempt(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}
+
+	got := p.backoff(1000)
+	if got != time.Minute {
+		t.Fatalf("expected a huge attempt count to saturate at MaxDelay, got %s", got)
+	}
+}
+
+func TestRetryPolicyBackoffWithoutMaxDelaySaturatesInsteadOfOverflowing(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second}
+
+	got := p.backoff(1000)
+	if got <= 0 {
+		t.Fatalf("expected backoff to saturate at a large positive duration, got %s", got)
+	}
+}
+
+// TestRetryPolicyBackoffWithJitterDoesNotOverflowWhenSaturated covers the
+// case where delay has already saturated at math.MaxInt64 (no MaxDelay
+// set): adding jitter on top used to overflow int64 and wrap to a negative
+// duration, which makes time.AfterFunc fire immediately instead of waiting.
+func TestRetryPolicyBackoffWithJitterDoesNotOverflowWhenSaturated(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, Jitter: true}
+
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1000); got <= 0 {
+			t.Fatalf("jittered backoff went non-positive (int64 overflow): %s", got)
+		}
+	}
+}
+
+// TestWithRetryAndTimeoutRetriesSuccessfully exercises the bug described in
+// chunk0-2/chunk0-3's review fix: a retried event reused the same ctx its
+// first attempt's WithTimeout had already cancelled, so it could never
+// actually retry. Each attempt must get its own fresh per-attempt deadline.
+func TestWithRetryAndTimeoutRetriesSuccessfully(t *testing.T) {
+	tk := New()
+	tk.Start()
+
+	var attempts int32
+	done := make(chan struct{})
+
+	err := tk.Enqueue(Request{
+		Job: "flaky-with-deadline",
+		F: func() error {
+			n := atomic.AddInt32(&attempts, 1)
+			if n < 3 {
+				return errors.New("not yet")
+			}
+			close(done)
+			return nil
+		},
+	}, WithTimeout(50*time.Millisecond), WithRetry(5, time.Millisecond, 0, false))
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("job never succeeded after retries, attempts=%d", atomic.LoadInt32(&attempts))
+	}
+}
+
+// TestShutdownWaitsForPendingRetry exercises the race described in
+// chunk0-2's review fix: Shutdown must not return (nor let the retry land
+// on the drained backend) while a retry's backoff timer is still pending.
+func TestShutdownWaitsForPendingRetry(t *testing.T) {
+	obs := &recordingObserver{}
+	tk := New(WithObserver(obs))
+	tk.Start()
+
+	attempts := 0
+	err := tk.Enqueue(Request{
+		Job: "flaky",
+		F: func() error {
+			attempts++
+			return errors.New("boom")
+		},
+	}, WithRetry(3, 100*time.Millisecond, 0, false))
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	// Give the first attempt a moment to run and schedule its retry, but
+	// call Shutdown well before the retry's backoff delay elapses.
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	remaining, err := tk.Shutdown(ctx)
+	if err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected no queued events handed back, got %d", len(remaining))
+	}
+
+	// The retry timer drops the event with ErrShutdown when it fires; that
+	// drop is only recorded by now if Shutdown waited for the timer.
+	drops := obs.drops()
+	if len(drops) != 1 || drops[0] != ErrShutdown {
+		t.Fatalf("expected Shutdown to wait out the pending retry and drop it with ErrShutdown, got drops %v", drops)
+	}
+}
@@ -0,0 +1,130 @@
+package tickler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteBackend persists queued events to a single SQLite file via
+// database/sql, letting a single-node deployment survive a restart
+// without standing up a separate queue service.
+type SQLiteBackend struct {
+	db       *sql.DB
+	registry JobRegistry
+}
+
+// NewSQLiteBackend opens (creating if necessary) the SQLite file at path
+// and prepares its schema. Transactions are opened with _txlock=immediate
+// so Pop's select-then-delete takes sqlite's write lock up front, letting
+// multiple processes share the file without two of them popping the same
+// row.
+func NewSQLiteBackend(path string, registry JobRegistry) (*SQLiteBackend, error) {
+	dsn := path
+	if strings.Contains(dsn, "?") {
+		dsn += "&_txlock=immediate"
+	} else {
+		dsn += "?_txlock=immediate"
+	}
+
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("tickler: open sqlite backend: %w", err)
+	}
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS tickler_events (
+			job         TEXT PRIMARY KEY,
+			priority    INTEGER NOT NULL,
+			enqueue_seq INTEGER NOT NULL,
+			payload     TEXT NOT NULL
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("tickler: migrate sqlite backend: %w", err)
+	}
+
+	return &SQLiteBackend{db: db, registry: registry}, nil
+}
+
+func (b *SQLiteBackend) Push(event *Event) error {
+	payload, err := json.Marshal(toPersisted(event))
+	if err != nil {
+		return fmt.Errorf("tickler: marshal event for sqlite: %w", err)
+	}
+
+	_, err = b.db.Exec(
+		`INSERT OR REPLACE INTO tickler_events (job, priority, enqueue_seq, payload) VALUES (?, ?, ?, ?)`,
+		event.Job, event.priority, event.enqueueSeq, payload,
+	)
+	return err
+}
+
+// Pop selects and deletes the highest-priority row inside one transaction,
+// so two processes sharing this file can't both select the same job (the
+// DSN's _txlock=immediate takes sqlite's write lock at BEGIN, before the
+// SELECT, serializing concurrent Pops). The row isn't deleted until after
+// toEvent has successfully resolved it against the registry, so a registry
+// miss leaves the job in place to retry later instead of losing it.
+func (b *SQLiteBackend) Pop() (*Event, error) {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("tickler: begin sqlite pop transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRow(`
+		SELECT job, payload FROM tickler_events
+		ORDER BY priority DESC, enqueue_seq ASC
+		LIMIT 1
+	`)
+
+	var job, payload string
+	if err := row.Scan(&job, &payload); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEmpty
+		}
+		return nil, fmt.Errorf("tickler: pop from sqlite: %w", err)
+	}
+
+	var persisted PersistedEvent
+	if err := json.Unmarshal([]byte(payload), &persisted); err != nil {
+		return nil, fmt.Errorf("tickler: unmarshal event for %q: %w", job, err)
+	}
+
+	event, err := persisted.toEvent(b.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM tickler_events WHERE job = ?`, job); err != nil {
+		return nil, fmt.Errorf("tickler: remove %q from sqlite: %w", job, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("tickler: commit sqlite pop transaction: %w", err)
+	}
+
+	return event, nil
+}
+
+func (b *SQLiteBackend) Ack(job JobName) error {
+	_, err := b.db.Exec(`DELETE FROM tickler_events WHERE job = ?`, job)
+	return err
+}
+
+// Nack is a no-op: Pop already removed job's row, and re-queuing it
+// requires a resolved BackgroundFn that only the caller has via Push.
+func (b *SQLiteBackend) Nack(job JobName, cause error) error {
+	return nil
+}
+
+func (b *SQLiteBackend) Len() int {
+	var n int
+	if err := b.db.QueryRow(`SELECT COUNT(*) FROM tickler_events`).Scan(&n); err != nil {
+		return 0
+	}
+	return n
+}
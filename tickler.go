@@ -1,10 +1,11 @@
 package tickler
 
 import (
-	"container/list"
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 )
 
 type (
@@ -26,44 +27,83 @@ const (
 )
 
 type Event struct {
-	fnOpts *eventOptions
-	Job    JobName
-	f      BackgroundFn
-	ctx    context.Context
-	result status
+	fnOpts    *eventOptions
+	Job       JobName
+	f         BackgroundFn
+	fc        BackgroundFnWithContext
+	parentCtx context.Context
+	ctx       context.Context
+	cancel    context.CancelFunc
+	result    status
+
+	priority   Priority
+	weight     int
+	enqueueSeq uint64
+	enqueuedAt time.Time
+	attempt    int
+	depsReady  bool
 
 	ch       chan struct{}
 	resultCh chan status
 }
 
+// beginAttempt (re)derives the event's per-attempt ctx/cancel from its
+// parentCtx. It must be called before every run of f/fc, including retries:
+// WithTimeout bounds a single run, not the event's whole lifetime across
+// retries and backoff waits, so each attempt gets its own fresh deadline
+// rather than inheriting (and possibly resuming into) one an earlier
+// attempt already cancelled.
+func (e *Event) beginAttempt() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	if e.fnOpts.timeout > 0 {
+		e.ctx, e.cancel = context.WithTimeout(e.parentCtx, e.fnOpts.timeout)
+	} else {
+		e.ctx, e.cancel = e.parentCtx, func() {}
+	}
+}
+
 type Request struct {
 	F   BackgroundFn
 	FC  BackgroundFnWithContext
 	Job JobName
 }
 
-func newEvent(ctx context.Context, request Request, opts ...EventOption) *Event {
+func newEvent(ctx context.Context, request Request, seq uint64, defaultAging time.Duration, opts ...EventOption) *Event {
 	t := &Event{
-		fnOpts:   &eventOptions{},
-		f:        request.F,
-		Job:      request.Job,
-		ctx:      ctx,
-		ch:       make(chan struct{}),
-		result:   statusSuccess,
-		resultCh: make(chan status),
+		fnOpts:     &eventOptions{priority: PriorityNormal, weight: 1, agingThreshold: defaultAging},
+		f:          request.F,
+		fc:         request.FC,
+		Job:        request.Job,
+		parentCtx:  ctx,
+		enqueueSeq: seq,
+		enqueuedAt: time.Now(),
+		ch:         make(chan struct{}),
+		result:     statusSuccess,
+		resultCh:   make(chan status),
 	}
 
 	for _, opt := range opts {
 		opt.apply(t.fnOpts)
 	}
 
+	t.priority = t.fnOpts.priority
+	t.weight = t.fnOpts.weight
+	t.beginAttempt()
+
 	return t
 }
 
 type eventOptions struct {
-	waitFor   []JobName
-	ifSuccess []JobName
-	ifFailure []JobName
+	waitFor        []JobName
+	ifSuccess      []JobName
+	ifFailure      []JobName
+	priority       Priority
+	weight         int
+	agingThreshold time.Duration
+	retry          *RetryPolicy
+	timeout        time.Duration
 }
 
 type EventOption interface {
@@ -102,31 +142,105 @@ func IfFailure(jobNames ...JobName) EventOption {
 	})
 }
 
+// WithPriority sets the priority an event is dequeued at. Defaults to
+// PriorityNormal.
+func WithPriority(p Priority) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		t.priority = p
+	})
+}
+
+// WithWeight sets a fairness weight for the event, for use by dequeue
+// strategies that want to favor some jobs over others within the same
+// priority band. Defaults to 1.
+func WithWeight(w int) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		t.weight = w
+	})
+}
+
+// WithTimeout bounds how long an event's background function may run
+// before its context is cancelled.
+func WithTimeout(d time.Duration) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		t.timeout = d
+	})
+}
+
+// WithAging sets how long an event may wait before its effective priority
+// is bumped by one level, preventing low-priority events from starving
+// behind a steady stream of higher-priority ones. A zero threshold
+// disables aging for the event.
+func WithAging(threshold time.Duration) EventOption {
+	return newEventOption(func(t *eventOptions) {
+		t.agingThreshold = threshold
+	})
+}
+
 type Options struct {
 	Limit int64
 	Ctx   context.Context
 
+	// AgingThreshold is the default aging threshold applied to events
+	// that don't set WithAging explicitly.
+	AgingThreshold time.Duration
+
 	sema chan int
 }
 
 type Tickler struct {
 	mu         sync.Mutex
 	ctx        context.Context
-	queue      *list.List
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	accepting  bool
+	backend    Backend
 	options    Options
 	loopSignal chan struct{}
+	enqueueSeq uint64
 
 	currentJobs map[JobName]bool
 	jobsWaitFor map[JobName][]chan struct{}
 	resultCh    map[JobName][]chan status
+
+	deps             map[JobName][]JobName
+	unknownJobPolicy UnknownJobPolicy
+
+	logger    Logger
+	observers []Observer
 }
 
-func (s *Tickler) EnqueueWithContext(ctx context.Context, request Request, opts ...EventOption) {
+// ErrShutdown is returned by Enqueue and EnqueueWithContext once Shutdown
+// has been called; the Tickler no longer accepts new work.
+var ErrShutdown = errors.New("tickler: shut down, not accepting new requests")
+
+// errJobFailed is passed to Backend.Nack when an event's own result was a
+// failure rather than a lower-level backend error.
+var errJobFailed = errors.New("tickler: job reported failure")
+
+// errDependencyFailed is reported to observers when an event is failed
+// because one of its IfSuccess/IfFailure dependencies didn't resolve the
+// way it required.
+var errDependencyFailed = errors.New("tickler: a required dependency did not complete successfully")
+
+func (s *Tickler) EnqueueWithContext(ctx context.Context, request Request, opts ...EventOption) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	if !s.accepting {
+		return ErrShutdown
+	}
 	defer s.tickleLoop()
 
-	ticklerEvent := newEvent(ctx, request, opts...)
+	s.enqueueSeq++
+	ticklerEvent := newEvent(ctx, request, s.enqueueSeq, s.options.AgingThreshold, opts...)
+
+	if err := s.resolveDependencies(ticklerEvent); err != nil {
+		return err
+	}
+	if err := s.checkCycle(ticklerEvent); err != nil {
+		return err
+	}
 
 	s.currentJobs[request.Job] = true
 
@@ -134,16 +248,40 @@ func (s *Tickler) EnqueueWithContext(ctx context.Context, request Request, opts
 		s.jobsWaitFor[v] = append(s.jobsWaitFor[v], ticklerEvent.ch)
 	}
 
-	s.queue.PushBack(ticklerEvent)
-	log.Printf("Added request to queue with length %d\n", s.queue.Len())
+	for _, v := range ticklerEvent.fnOpts.ifSuccess {
+		s.resultCh[v] = append(s.resultCh[v], ticklerEvent.resultCh)
+	}
+
+	for _, v := range ticklerEvent.fnOpts.ifFailure {
+		s.resultCh[v] = append(s.resultCh[v], ticklerEvent.resultCh)
+	}
+
+	if err := s.backend.Push(ticklerEvent); err != nil {
+		return fmt.Errorf("tickler: push to backend: %w", err)
+	}
+	s.logger.Infof("Added request to queue with length %d\n", s.backend.Len())
+	s.notifyEnqueue(ticklerEvent)
+	return nil
 }
 
-func (s *Tickler) Enqueue(request Request, opts ...EventOption) {
+func (s *Tickler) Enqueue(request Request, opts ...EventOption) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	if !s.accepting {
+		return ErrShutdown
+	}
 	defer s.tickleLoop()
 
-	ticklerEvent := newEvent(context.Background(), request, opts...)
+	s.enqueueSeq++
+	ticklerEvent := newEvent(context.Background(), request, s.enqueueSeq, s.options.AgingThreshold, opts...)
+
+	if err := s.resolveDependencies(ticklerEvent); err != nil {
+		return err
+	}
+	if err := s.checkCycle(ticklerEvent); err != nil {
+		return err
+	}
 
 	s.currentJobs[request.Job] = true
 
@@ -155,18 +293,26 @@ func (s *Tickler) Enqueue(request Request, opts ...EventOption) {
 		s.resultCh[v] = append(s.resultCh[v], ticklerEvent.resultCh)
 	}
 
-	s.queue.PushBack(ticklerEvent)
-	log.Printf("Added request to queue with length %d\n", s.queue.Len())
+	for _, v := range ticklerEvent.fnOpts.ifFailure {
+		s.resultCh[v] = append(s.resultCh[v], ticklerEvent.resultCh)
+	}
+
+	if err := s.backend.Push(ticklerEvent); err != nil {
+		return fmt.Errorf("tickler: push to backend: %w", err)
+	}
+	s.logger.Infof("Added request to queue with length %d\n", s.backend.Len())
+	s.notifyEnqueue(ticklerEvent)
+	return nil
 }
 
 func (s *Tickler) loop() {
-	log.Println("Starting service loop")
+	s.logger.Infof("Starting service loop")
 	for {
 		select {
 		case <-s.loopSignal:
 			s.tryDequeue()
 		case <-s.ctx.Done():
-			log.Printf("Loop context cancelled")
+			s.logger.Infof("Loop context cancelled")
 			return
 		}
 	}
@@ -175,27 +321,36 @@ func (s *Tickler) loop() {
 func (s *Tickler) tryDequeue() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.queue.Len() == 0 {
+	if s.backend.Len() == 0 {
 		return
 	}
 
 	select {
 	case s.options.sema <- 1:
-		request := s.dequeue()
-		log.Printf("Dequeued request %v\n", request)
+		request, err := s.backend.Pop()
+		if err != nil {
+			<-s.options.sema
+			s.logger.Errorf("Failed to pop request from backend: %v", err)
+			return
+		}
+		s.logger.Infof("Dequeued request %v\n", request)
+		s.notifyDequeue(request)
+		s.wg.Add(1)
 		go s.process(request)
 	default:
-		log.Printf("Received loop signal, but request limit is reached")
+		s.logger.Debugf("Received loop signal, but request limit is reached")
 	}
 }
 
-func (s *Tickler) dequeue() *Event {
-	element := s.queue.Front()
-	s.queue.Remove(element)
-	return element.Value.(*Event)
-}
-
 func (s *Tickler) removeJob(event *Event) {
+	if event.result == statusSuccess {
+		if err := s.backend.Ack(event.Job); err != nil {
+			s.logger.Errorf("failed to ack job %v on backend: %v", event.Job, err)
+		}
+	} else if err := s.backend.Nack(event.Job, errJobFailed); err != nil {
+		s.logger.Errorf("failed to nack job %v on backend: %v", event.Job, err)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -209,6 +364,7 @@ func (s *Tickler) removeJob(event *Event) {
 
 	delete(s.jobsWaitFor, event.Job)
 	delete(s.currentJobs, event.Job)
+	delete(s.deps, event.Job)
 }
 
 type eventResults struct {
@@ -218,57 +374,114 @@ type eventResults struct {
 }
 
 func (s *Tickler) process(event *Event) {
+	defer s.wg.Done()
 	defer s.replenish()
-	defer s.removeJob(event)
+	// event.cancel is reassigned by beginAttempt below, so defer a closure
+	// over event rather than the cancel func in scope now: this must cancel
+	// *this* attempt's context, not whichever one existed when process()
+	// was entered.
+	defer func() { event.cancel() }()
+
+	// Dependencies are only waited on once: a retried event has already
+	// consumed its dependencies' single completion signal on its first
+	// attempt, so re-running the wait loop on subsequent attempts would
+	// block forever.
+	if !event.depsReady {
+		cnt := len(event.fnOpts.waitFor)
+		eventRes := eventResults{
+			SucceededEvents: len(event.fnOpts.ifSuccess),
+			FailedEvents:    len(event.fnOpts.ifFailure),
+			TotalEvents:     len(event.fnOpts.ifSuccess) + len(event.fnOpts.ifFailure),
+		}
 
-	cnt := len(event.fnOpts.waitFor)
-	eventRes := eventResults{
-		SucceededEvents: len(event.fnOpts.ifSuccess),
-		FailedEvents:    len(event.fnOpts.ifFailure),
-		TotalEvents:     len(event.fnOpts.ifSuccess) + len(event.fnOpts.ifFailure),
-	}
+		// Wait for other jobs to be done
+		for {
+			if cnt < 1 && eventRes.TotalEvents == 0 {
+				break
+			}
 
-	// Wait for other jobs to be done
-	for {
-		if cnt < 1 && eventRes.TotalEvents == 0 {
-			break
+			select {
+			case <-event.ch:
+				cnt--
+			case r := <-event.resultCh:
+				eventRes.TotalEvents--
+				if r == statusSuccess {
+					eventRes.SucceededEvents--
+				} else {
+					eventRes.FailedEvents--
+				}
+			}
 		}
 
-		select {
-		case <-event.ch:
-			cnt--
-		case r := <-event.resultCh:
-			eventRes.TotalEvents--
-			if r == statusSuccess {
-				eventRes.SucceededEvents--
-			} else {
-				eventRes.FailedEvents--
-			}
+		// If all jobs are done, then we can proceed
+		if eventRes.SucceededEvents != 0 || eventRes.FailedEvents != 0 {
+			event.result = statusFailure
+			s.notifyDrop(event, errDependencyFailed)
+			s.removeJob(event)
+			return
 		}
+
+		event.depsReady = true
 	}
 
-	// If all jobs are done, then we can proceed
-	if eventRes.SucceededEvents != 0 || eventRes.FailedEvents != 0 {
+	// Re-derive ctx/cancel for this attempt: WithTimeout bounds a single
+	// run of f/fc, not the event's whole lifetime, so a retry must not run
+	// against the previous attempt's (already cancelled) context.
+	event.beginAttempt()
+
+	select {
+	case <-event.ctx.Done():
+		err := event.ctx.Err()
+		s.logger.Errorf("event context cancelled for %v before it started: %v", event.Job, err)
 		event.result = statusFailure
+		s.notifyComplete(event, err)
+		s.removeJob(event)
 		return
+	default:
 	}
 
+	s.notifyStart(event)
+
+	done := make(chan error, 1)
+	go func() {
+		if event.fc != nil {
+			done <- event.fc(event.ctx)
+			return
+		}
+		done <- event.f()
+	}()
+
+	var err error
 	select {
 	case <-event.ctx.Done():
-		log.Printf("event context cancelled for %v", event.Job)
+		err = event.ctx.Err()
+		s.logger.Errorf("event context cancelled for %v mid-execution: %v", event.Job, err)
+		event.result = statusFailure
+		s.notifyComplete(event, err)
+		s.removeJob(event)
 		return
-	default:
+	case err = <-done:
+	}
 
-		if err := event.f(); err != nil {
-			log.Printf("background task got error: %v", err)
-			event.result = statusFailure
+	if err != nil {
+		s.logger.Errorf("background task got error: %v", err)
+
+		if event.fnOpts.retry != nil && event.fnOpts.retry.shouldRetry(event.attempt, err) {
+			s.notifyRetry(event, event.attempt+1, err)
+			s.scheduleRetry(event, err)
+			return
 		}
+
+		event.result = statusFailure
 	}
+
+	s.notifyComplete(event, err)
+	s.removeJob(event)
 }
 
 func (s *Tickler) replenish() {
 	<-s.options.sema
-	log.Printf("Replenishing semaphore, now %d/%d slots in use\n", len(s.options.sema), cap(s.options.sema))
+	s.logger.Debugf("Replenishing semaphore, now %d/%d slots in use\n", len(s.options.sema), cap(s.options.sema))
 	s.tickleLoop()
 }
 
@@ -283,24 +496,107 @@ func (s *Tickler) Start() {
 	go s.loop()
 }
 
-func (s *Tickler) Stop() {
-	ctx, cancel := context.WithCancel(s.ctx)
-	defer cancel()
-	s.ctx = ctx
+// Shutdown stops the Tickler gracefully: it stops accepting new enqueues,
+// signals the service loop to exit, and waits for all in-flight process()
+// goroutines to finish or for ctx to expire, whichever comes first. It
+// returns any events still sitting in the queue, unstarted, so the caller
+// can inspect or re-enqueue them elsewhere.
+func (s *Tickler) Shutdown(ctx context.Context) ([]*Event, error) {
+	s.mu.Lock()
+	s.accepting = false
+	s.cancel()
+	remaining := drainAll(s.backend)
+	s.mu.Unlock()
+
+	for _, event := range remaining {
+		s.notifyDrop(event, ErrShutdown)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return remaining, nil
+	case <-ctx.Done():
+		s.logger.Errorf("shutdown deadline exceeded with in-flight jobs remaining: %v", ctx.Err())
+		return remaining, ctx.Err()
+	}
+}
+
+// Drain runs every event currently in the queue to completion before
+// returning, running at most the request limit's worth of events at once.
+// Unlike Shutdown it leaves the
+// Tickler accepting new work afterward.
+func (s *Tickler) Drain() {
+	for {
+		s.mu.Lock()
+		empty := s.backend.Len() == 0
+		s.mu.Unlock()
+		if empty {
+			break
+		}
+
+		select {
+		case s.options.sema <- 1:
+			s.mu.Lock()
+			request, err := s.backend.Pop()
+			s.mu.Unlock()
+			if err != nil {
+				<-s.options.sema
+				continue
+			}
+			s.notifyDequeue(request)
+			s.wg.Add(1)
+			go s.process(request)
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	s.wg.Wait()
 }
 
 // New creates a new Tickler with default settings.
-func New() *Tickler {
+func New(opts ...TicklerOption) *Tickler {
+	return NewWithBackend(newMemoryBackend(), opts...)
+}
+
+// NewWithBackend creates a new Tickler that stores its queue in backend
+// instead of the in-process default, e.g. a RedisBackend or SQLiteBackend
+// so queued jobs survive a process restart. Note that s.currentJobs and
+// s.deps, used to validate WaitForJobs/IfSuccess/IfFailure dependencies
+// and detect cycles, are rebuilt only from events enqueued in this
+// process; a Tickler resumed against an already-populated persistent
+// backend doesn't know about jobs enqueued before the restart, and
+// dequeuing them does not add them to either map.
+func NewWithBackend(backend Backend, opts ...TicklerOption) *Tickler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cfg := &tickerConfig{logger: stdLogger{}}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
 	service := &Tickler{
-		queue: list.New(),
+		backend: backend,
 		options: Options{
-			sema: make(chan int, defaultRequestLimit),
+			AgingThreshold: defaultAgingThreshold,
+			sema:           make(chan int, defaultRequestLimit),
 		},
-		ctx:         context.Background(),
+		ctx:         ctx,
+		cancel:      cancel,
+		accepting:   true,
 		loopSignal:  make(chan struct{}, defaultRequestLimit),
 		currentJobs: make(map[string]bool),
 		jobsWaitFor: make(map[string][]chan struct{}),
 		resultCh:    make(map[string][]chan status),
+		deps:        make(map[JobName][]JobName),
+		logger:      cfg.logger,
+		observers:   cfg.observers,
 	}
 
 	return service
@@ -0,0 +1,56 @@
+package tickler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDrainRunsQueuedEventsToCompletion(t *testing.T) {
+	tk := New()
+
+	const n = 5
+	done := make(chan JobName, n)
+	for i := 0; i < n; i++ {
+		job := JobName(string(rune('a' + i)))
+		if err := tk.Enqueue(Request{
+			Job: job,
+			F:   func() error { done <- job; return nil },
+		}); err != nil {
+			t.Fatalf("enqueue %s: %v", job, err)
+		}
+	}
+
+	tk.Drain()
+
+	if tk.backend.Len() != 0 {
+		t.Fatalf("expected backend to be empty after Drain, got length %d", tk.backend.Len())
+	}
+	if len(done) != n {
+		t.Fatalf("expected all %d jobs to have run, got %d", n, len(done))
+	}
+}
+
+func TestShutdownStopsAcceptingAndReturnsUnstarted(t *testing.T) {
+	tk := New()
+	// No Start(): the loop never dequeues, so the event stays queued.
+
+	if err := tk.Enqueue(Request{Job: "queued", F: func() error { return nil }}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	remaining, err := tk.Shutdown(ctx)
+	if err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Job != "queued" {
+		t.Fatalf("expected the unstarted event back from Shutdown, got %+v", remaining)
+	}
+
+	if err := tk.Enqueue(Request{Job: "too-late", F: func() error { return nil }}); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("expected ErrShutdown after Shutdown, got %v", err)
+	}
+}
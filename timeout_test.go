@@ -0,0 +1,65 @@
+package tickler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithTimeoutCancelsMidExecution(t *testing.T) {
+	tk := New()
+	tk.Start()
+
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+
+	err := tk.Enqueue(Request{
+		Job: "slow",
+		FC: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return ctx.Err()
+		},
+	}, WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("job never started")
+	}
+
+	select {
+	case cerr := <-cancelled:
+		if !errors.Is(cerr, context.DeadlineExceeded) {
+			t.Errorf("ctx.Err() = %v, want context.DeadlineExceeded", cerr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the timeout to cancel the job's context")
+	}
+}
+
+// TestBeginAttemptGivesEachAttemptAFreshDeadline covers the fix for
+// WithTimeout bounding a single run of f/fc rather than the event's whole
+// lifetime: previously the deadline was computed once at enqueue and shared
+// across every retry and its backoff wait, so it could (and, combined with
+// the stale-cancelled-ctx bug, always did) expire before a later attempt
+// ever started. beginAttempt must hand out a context whose deadline is
+// measured from when that attempt begins, not from construction.
+func TestBeginAttemptGivesEachAttemptAFreshDeadline(t *testing.T) {
+	event := newEvent(context.Background(), Request{Job: "job", F: func() error { return nil }}, 1, 0,
+		WithTimeout(20*time.Millisecond))
+
+	<-event.ctx.Done() // let the first attempt's deadline lapse
+
+	event.beginAttempt()
+	select {
+	case <-event.ctx.Done():
+		t.Fatal("expected beginAttempt to hand out a fresh, not-yet-expired deadline")
+	default:
+	}
+}